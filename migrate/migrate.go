@@ -0,0 +1,174 @@
+// Package migrate provides a small ordered schema migration runner for a
+// *mongo.Database, with progress tracked in a "schema_migrations" collection.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// migrationsCollection is where applied migrations and the advisory lock
+// are recorded.
+const migrationsCollection = "schema_migrations"
+
+// lockDocID is the _id of the advisory lock document.
+const lockDocID = "lock"
+
+// Version identifies a migration's position in the ordered sequence.
+// Migrations apply in ascending Version order.
+type Version int
+
+// Migration is one schema change bound to a Version. Up must be provided;
+// Down is optional and, if set, is used to roll back this migration when a
+// later one in the same Apply call fails.
+type Migration struct {
+	Version     Version
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	Down        func(ctx context.Context, db *mongo.Database) error
+}
+
+// appliedMigration is the document recorded in migrationsCollection once a
+// Migration's Up has run successfully.
+type appliedMigration struct {
+	ID          Version   `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"appliedAt"`
+}
+
+// Migrator holds an ordered set of registered Migrations and applies them
+// against a *mongo.Database.
+type Migrator struct {
+	migrations []Migration
+}
+
+// New is a constructor.
+func New() *Migrator {
+	return &Migrator{}
+}
+
+// Register adds a migration to the set. Migrations run in ascending
+// Version order regardless of registration order.
+func (m *Migrator) Register(migration Migration) {
+	m.migrations = append(m.migrations, migration)
+}
+
+// Current returns the highest Version successfully applied against db, or
+// zero if none has been applied yet.
+func (m *Migrator) Current(ctx context.Context, db *mongo.Database) (Version, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "_id", Value: -1}})
+	var doc appliedMigration
+	errFindOne := db.Collection(migrationsCollection).
+		FindOne(ctx, bson.M{"_id": bson.M{"$ne": lockDocID}}, opts).
+		Decode(&doc)
+	switch {
+	case errFindOne == mongo.ErrNoDocuments:
+		return 0, nil
+	case errFindOne != nil:
+		return 0, fmt.Errorf("finding applied migrations: %v", errFindOne)
+	}
+	return doc.ID, nil
+}
+
+// Apply runs every registered migration whose Version is greater than the
+// version currently recorded in db, up to and including targetVersion, in
+// ascending order. It acquires an advisory lock (an insert-with-unique-key
+// document with _id:"lock") before running so concurrent callers don't
+// race, and releases it afterwards. If a migration's Up fails, every
+// migration already applied during this call is rolled back in reverse
+// order.
+func (m *Migrator) Apply(ctx context.Context, db *mongo.Database, targetVersion Version) error {
+	pending := make([]Migration, len(m.migrations))
+	copy(pending, m.migrations)
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Version < pending[j].Version })
+
+	collection := db.Collection(migrationsCollection)
+	if errLock := acquireLock(ctx, collection); errLock != nil {
+		return errLock
+	}
+	defer releaseLock(ctx, collection)
+
+	current, errCurrent := m.Current(ctx, db)
+	if errCurrent != nil {
+		return errCurrent
+	}
+
+	var applied []Migration
+	for _, migration := range pending {
+		if migration.Version <= current || migration.Version > targetVersion {
+			continue
+		}
+		if errUp := migration.Up(ctx, db); errUp != nil {
+			rollback(ctx, db, applied)
+			return fmt.Errorf("applying migration %d (%s): %v", migration.Version, migration.Description, errUp)
+		}
+		record := appliedMigration{
+			ID:          migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now(),
+		}
+		if _, errInsert := collection.InsertOne(ctx, record); errInsert != nil {
+			rollback(ctx, db, append(applied, migration))
+			return fmt.Errorf("recording migration %d: %v", migration.Version, errInsert)
+		}
+		applied = append(applied, migration)
+	}
+	return nil
+}
+
+// rollback runs Down, in reverse order, for every migration in applied, and
+// removes their recorded documents. Errors are best-effort; a failed Down
+// doesn't stop the rest of the rollback.
+func rollback(ctx context.Context, db *mongo.Database, applied []Migration) {
+	collection := db.Collection(migrationsCollection)
+	for i := len(applied) - 1; i >= 0; i-- {
+		migration := applied[i]
+		if migration.Down != nil {
+			_ = migration.Down(ctx, db)
+		}
+		_, _ = collection.DeleteOne(ctx, bson.M{"_id": migration.Version})
+	}
+}
+
+// acquireLock inserts the advisory lock document, failing if it's already
+// held.
+func acquireLock(ctx context.Context, collection *mongo.Collection) error {
+	lock := bson.M{"_id": lockDocID, "acquiredAt": time.Now()}
+	if _, errInsert := collection.InsertOne(ctx, lock); errInsert != nil {
+		return fmt.Errorf("acquiring migration lock: %v", errInsert)
+	}
+	return nil
+}
+
+// releaseLock removes the advisory lock document.
+func releaseLock(ctx context.Context, collection *mongo.Collection) {
+	_, _ = collection.DeleteOne(ctx, bson.M{"_id": lockDocID})
+}
+
+// CollectionIndexes declares the set of mongo.IndexModel that a collection
+// should have, so migrations can express indexes declaratively instead of
+// hand-rolling CreateMany calls.
+type CollectionIndexes struct {
+	Collection string
+	Indexes    []mongo.IndexModel
+}
+
+// EnsureIndexes creates every index declared in specs on its collection.
+// Intended to be called from within a Migration's Up.
+func EnsureIndexes(ctx context.Context, db *mongo.Database, specs ...CollectionIndexes) error {
+	for _, spec := range specs {
+		if len(spec.Indexes) == 0 {
+			continue
+		}
+		if _, errCreate := db.Collection(spec.Collection).Indexes().CreateMany(ctx, spec.Indexes); errCreate != nil {
+			return fmt.Errorf("creating indexes on %s: %v", spec.Collection, errCreate)
+		}
+	}
+	return nil
+}
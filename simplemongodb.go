@@ -4,11 +4,15 @@ package simplemongodb
 import (
 	"context"
 	"errors"
-	"fmt"
+	"io"
 	"sync"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
 	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/NaniteFactory/simplemongodb/migrate"
 )
 
 // New is a constructor.
@@ -19,11 +23,21 @@ func New() SimpleMongoDB {
 // SimpleMongoDB wraps around a single DB with a single mongodb client.
 type SimpleMongoDB interface {
 	Connect(ctx context.Context, uri, nameDB string, nameCollections ...string) error
+	ConnectStrict(ctx context.Context, uri, nameDB string, nameCollections ...string) error
+	ConnectWithConfig(ctx context.Context, cfg ConnectionConfig) error
+	ConnectWithOptions(ctx context.Context, cfg ConnectOptions) error
 	Disconnect(ctx context.Context) error
 	IsConnected() bool
 	Client() *mongo.Client
 	Database() *mongo.Database
 	Collection(name string, opts ...*options.CollectionOptions) *mongo.Collection
+	Collections(ctx context.Context) ([]string, error)
+	Bulk(collectionName string, size int) *BulkBuffer
+	HasCollection(ctx context.Context, name string) (bool, error)
+	Migrate(ctx context.Context, migrations []migrate.Migration) error
+	Bucket(name string, opts ...*options.BucketOptions) (*gridfs.Bucket, error)
+	UploadFromStream(ctx context.Context, bucketName, filename string, r io.Reader, opts ...*options.UploadOptions) (primitive.ObjectID, int64, error)
+	DownloadToStream(ctx context.Context, bucketName, filename string, w io.Writer) (int64, error)
 }
 
 // simpleMongoDB wraps around a single DB with a single mongodb client.
@@ -34,6 +48,15 @@ type simpleMongoDB struct {
 	database      *mongo.Database
 	collections   map[string]*mongo.Collection
 	collectionsMu sync.RWMutex
+	buckets       map[string]*gridfs.Bucket
+	bucketsMu     sync.RWMutex
+
+	// healthCfg, healthCancel and healthDone back the background health
+	// check supervisor started by ConnectWithOptions. healthCancel is nil
+	// when no supervisor is running.
+	healthCfg    ConnectOptions
+	healthCancel context.CancelFunc
+	healthDone   chan struct{}
 }
 
 func (smdb *simpleMongoDB) isConnected() bool {
@@ -50,72 +73,39 @@ func (smdb *simpleMongoDB) disconnect() {
 		smdb.client = nil
 		smdb.database = nil
 		smdb.collections = nil
+		smdb.bucketsMu.Lock()
+		smdb.buckets = nil
+		smdb.bucketsMu.Unlock()
 	}
 }
 
 // Connect to a single DB with a single mongo client.
 // Errors if it cannot reach any of desired database or collections.
 // Call Disconnect method to close down connection.
+// This is a thin wrapper around ConnectWithConfig for the common case of a
+// bare URI with no TLS/credential configuration.
 func (smdb *simpleMongoDB) Connect(ctx context.Context, uri, nameDB string, nameCollections ...string) error {
-	// write lock
-	smdb.mu.Lock()
-	defer smdb.mu.Unlock()
-	// validate
-	if smdb.isConnected() {
-		return errors.New("already connected")
-	}
-	{ // construct a client
-		// the return is assign to a local variable in case of error violating the global
-		mdc, errClient := mongo.NewClient(options.Client().
-			ApplyURI(uri).
-			SetRetryWrites(false))
-		if errClient != nil {
-			return fmt.Errorf("creating client: %v", errClient)
-		}
-		smdb.client = mdc
-	}
-	// connect
-	if errConnect := smdb.client.Connect(ctx); errConnect != nil {
-		return fmt.Errorf("connecting client: %v", errConnect)
-	}
-	// ping
-	if errPing := smdb.client.Ping(ctx, nil); errPing != nil {
-		smdb.disconnect()
-		return fmt.Errorf("sending ping: %v", errPing)
-	}
-	// get database
-	smdb.database = smdb.client.Database(nameDB)
-	if smdb.database == nil {
-		smdb.disconnect()
-		return fmt.Errorf("cannot get database: %v", nameDB)
-	}
-	// set collection
-	smdb.collectionsMu.Lock()
-	smdb.collections = map[string]*mongo.Collection{}
-	smdb.collectionsMu.Unlock()
-	for _, nameCollection := range nameCollections {
-		collection := smdb.database.Collection(nameCollection)
-		if collection == nil {
-			smdb.disconnect()
-			return fmt.Errorf("cannot get collection: %v", nameCollection)
-		}
-		smdb.collectionsMu.Lock()
-		smdb.collections[nameCollection] = smdb.database.Collection(nameCollection)
-		smdb.collectionsMu.Unlock()
-	}
-	// return
-	return nil
+	return smdb.ConnectWithConfig(ctx, ConnectionConfig{
+		URI:         uri,
+		DB:          nameDB,
+		Collections: nameCollections,
+	})
 }
 
 // Disconnect the connection to DB.
 func (smdb *simpleMongoDB) Disconnect(ctx context.Context) error {
+	smdb.stopHealthSupervisor()
 	// write lock
 	smdb.mu.Lock()
 	defer smdb.mu.Unlock()
 	if !smdb.isConnected() {
 		return errors.New("not connected")
 	}
-	return smdb.client.Disconnect(ctx)
+	errDisconnect := smdb.client.Disconnect(ctx)
+	smdb.bucketsMu.Lock()
+	smdb.buckets = nil
+	smdb.bucketsMu.Unlock()
+	return errDisconnect
 }
 
 // IsConnected tells if this is connected.
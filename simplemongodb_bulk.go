@@ -0,0 +1,170 @@
+package simplemongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// defaultMaxBSONSize is the MongoDB command size limit a single BulkWrite
+// batch must stay under.
+const defaultMaxBSONSize = 16 * 1024 * 1024
+
+// BulkBuffer accumulates mongo.WriteModel values and flushes them with
+// Collection.BulkWrite once the buffer reaches its configured size, Flush
+// is called, or appending the next model would exceed MaxBSONSize. Safe
+// for concurrent Append from multiple goroutines -- flushes happen under
+// the same lock as Append, so ordering is preserved within a single
+// BulkBuffer.
+type BulkBuffer struct {
+	mu         sync.Mutex
+	collection *mongo.Collection
+	size       int
+
+	// Ordered is passed through to BulkWrite. Defaults to true.
+	Ordered bool
+	// MaxBSONSize guards a single batch from exceeding the 16MB command
+	// limit. Defaults to defaultMaxBSONSize.
+	MaxBSONSize int
+
+	models      []mongo.WriteModel
+	modelsBytes int
+	err         error
+
+	InsertedCount int64
+	MatchedCount  int64
+	ModifiedCount int64
+	UpsertedCount int64
+	DeletedCount  int64
+}
+
+// Bulk returns a BulkBuffer bound to the named collection (via the
+// collection cache) that auto-flushes every size appended write models.
+// If this wrapper isn't connected, Collection returns nil and the returned
+// BulkBuffer carries that as its Err() instead of panicking on first use.
+func (smdb *simpleMongoDB) Bulk(collectionName string, size int) *BulkBuffer {
+	collection := smdb.Collection(collectionName)
+	buf := &BulkBuffer{
+		collection:  collection,
+		size:        size,
+		Ordered:     true,
+		MaxBSONSize: defaultMaxBSONSize,
+	}
+	if collection == nil {
+		buf.err = errors.New("not connected")
+	}
+	return buf
+}
+
+// Append adds a write model to the buffer, auto-flushing first if it would
+// push the batch past MaxBSONSize, and again afterwards once the buffer
+// reaches its configured size.
+func (b *BulkBuffer) Append(ctx context.Context, model mongo.WriteModel) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return b.err
+	}
+	modelBytes, errSize := writeModelSize(model)
+	if errSize != nil {
+		b.setErr(errSize)
+		return errSize
+	}
+	if len(b.models) > 0 && b.modelsBytes+modelBytes > b.MaxBSONSize {
+		if errFlush := b.flushLocked(ctx); errFlush != nil {
+			return errFlush
+		}
+	}
+	b.models = append(b.models, model)
+	b.modelsBytes += modelBytes
+	if len(b.models) >= b.size {
+		return b.flushLocked(ctx)
+	}
+	return nil
+}
+
+// Flush writes any buffered models now, regardless of buffer size.
+func (b *BulkBuffer) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return b.err
+	}
+	return b.flushLocked(ctx)
+}
+
+// Err returns the first error encountered by this BulkBuffer, if any.
+func (b *BulkBuffer) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
+
+// flushLocked runs BulkWrite on the buffered models. Caller must hold mu.
+func (b *BulkBuffer) flushLocked(ctx context.Context) error {
+	if b.collection == nil {
+		err := errors.New("not connected")
+		b.setErr(err)
+		return err
+	}
+	if len(b.models) == 0 {
+		return nil
+	}
+	models := b.models
+	b.models = nil
+	b.modelsBytes = 0
+	result, errBulk := b.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(b.Ordered))
+	if errBulk != nil {
+		b.setErr(errBulk)
+		return errBulk
+	}
+	b.InsertedCount += result.InsertedCount
+	b.MatchedCount += result.MatchedCount
+	b.ModifiedCount += result.ModifiedCount
+	b.UpsertedCount += result.UpsertedCount
+	b.DeletedCount += result.DeletedCount
+	return nil
+}
+
+// setErr records err if this is the first one seen. Caller must hold mu.
+func (b *BulkBuffer) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// writeModelSize estimates the BSON-encoded size of a write model's
+// documents, for the MaxBSONSize guard.
+func writeModelSize(model mongo.WriteModel) (int, error) {
+	var docs []interface{}
+	switch m := model.(type) {
+	case *mongo.InsertOneModel:
+		docs = append(docs, m.Document)
+	case *mongo.UpdateOneModel:
+		docs = append(docs, m.Filter, m.Update)
+	case *mongo.UpdateManyModel:
+		docs = append(docs, m.Filter, m.Update)
+	case *mongo.ReplaceOneModel:
+		docs = append(docs, m.Filter, m.Replacement)
+	case *mongo.DeleteOneModel:
+		docs = append(docs, m.Filter)
+	case *mongo.DeleteManyModel:
+		docs = append(docs, m.Filter)
+	default:
+		return 0, fmt.Errorf("unsupported write model type %T", model)
+	}
+	total := 0
+	for _, doc := range docs {
+		raw, errMarshal := bson.Marshal(doc)
+		if errMarshal != nil {
+			return 0, fmt.Errorf("marshaling write model: %v", errMarshal)
+		}
+		total += len(raw)
+	}
+	return total, nil
+}
@@ -0,0 +1,171 @@
+package simplemongodb
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// ConnectionConfig carries the parameters for ConnectWithConfig, it covers
+// the TLS/x509 and SCRAM credential knobs that the plain Connect cannot
+// express.
+type ConnectionConfig struct {
+	URI         string
+	DB          string
+	Collections []string
+
+	// TLSConfig, if set, is used as the base TLS configuration. CAFile and
+	// ClientCertFile/ClientKeyFile, if also set, are layered on top of it.
+	TLSConfig      *tls.Config
+	CAFile         string
+	ClientCertFile string
+	ClientKeyFile  string
+
+	// Credential configures SCRAM, x509 or PLAIN authentication.
+	Credential *options.Credential
+
+	AppName                string
+	ReadPreference         *readpref.ReadPref
+	WriteConcern           *writeconcern.WriteConcern
+	RetryWrites            *bool
+	ServerSelectionTimeout time.Duration
+
+	// ClientOptionsFunc, if set, is called last with the assembled client
+	// options as an escape hatch for anything not covered above.
+	ClientOptionsFunc func(*options.ClientOptions)
+}
+
+// ConnectWithConfig connects to a single DB with a single mongo client,
+// same as Connect, but accepts a ConnectionConfig for TLS/x509 and SCRAM
+// credentials instead of hard-coding them from the URI alone.
+// Errors if it cannot reach any of desired database or collections.
+// Call Disconnect method to close down connection.
+func (smdb *simpleMongoDB) ConnectWithConfig(ctx context.Context, cfg ConnectionConfig) error {
+	// write lock
+	smdb.mu.Lock()
+	defer smdb.mu.Unlock()
+	// validate
+	if smdb.isConnected() {
+		return errors.New("already connected")
+	}
+	clientOpts, errOpts := buildClientOptions(cfg)
+	if errOpts != nil {
+		return fmt.Errorf("building client options: %v", errOpts)
+	}
+	{ // construct a client
+		// the return is assign to a local variable in case of error violating the global
+		mdc, errClient := mongo.NewClient(clientOpts)
+		if errClient != nil {
+			return fmt.Errorf("creating client: %v", errClient)
+		}
+		smdb.client = mdc
+	}
+	// connect
+	if errConnect := smdb.client.Connect(ctx); errConnect != nil {
+		return fmt.Errorf("connecting client: %v", errConnect)
+	}
+	// ping
+	if errPing := smdb.client.Ping(ctx, nil); errPing != nil {
+		smdb.disconnect()
+		return fmt.Errorf("sending ping: %v", errPing)
+	}
+	// get database
+	smdb.database = smdb.client.Database(cfg.DB)
+	if smdb.database == nil {
+		smdb.disconnect()
+		return fmt.Errorf("cannot get database: %v", cfg.DB)
+	}
+	// set collection
+	smdb.collectionsMu.Lock()
+	smdb.collections = map[string]*mongo.Collection{}
+	smdb.collectionsMu.Unlock()
+	for _, nameCollection := range cfg.Collections {
+		collection := smdb.database.Collection(nameCollection)
+		if collection == nil {
+			smdb.disconnect()
+			return fmt.Errorf("cannot get collection: %v", nameCollection)
+		}
+		smdb.collectionsMu.Lock()
+		smdb.collections[nameCollection] = collection
+		smdb.collectionsMu.Unlock()
+	}
+	// return
+	return nil
+}
+
+// buildClientOptions assembles *options.ClientOptions from a ConnectionConfig.
+func buildClientOptions(cfg ConnectionConfig) (*options.ClientOptions, error) {
+	opts := options.Client().ApplyURI(cfg.URI)
+	if cfg.AppName != "" {
+		opts.SetAppName(cfg.AppName)
+	}
+	if cfg.ReadPreference != nil {
+		opts.SetReadPreference(cfg.ReadPreference)
+	}
+	if cfg.WriteConcern != nil {
+		opts.SetWriteConcern(cfg.WriteConcern)
+	}
+	if cfg.ServerSelectionTimeout > 0 {
+		opts.SetServerSelectionTimeout(cfg.ServerSelectionTimeout)
+	}
+	if cfg.Credential != nil {
+		opts.SetAuth(*cfg.Credential)
+	}
+	if cfg.RetryWrites != nil {
+		opts.SetRetryWrites(*cfg.RetryWrites)
+	} else {
+		opts.SetRetryWrites(false)
+	}
+	tlsConfig, errTLS := buildTLSConfig(cfg)
+	if errTLS != nil {
+		return nil, errTLS
+	}
+	if tlsConfig != nil {
+		opts.SetTLSConfig(tlsConfig)
+	}
+	if cfg.ClientOptionsFunc != nil {
+		cfg.ClientOptionsFunc(opts)
+	}
+	return opts, nil
+}
+
+// buildTLSConfig loads the PEM CA and client cert/key named in cfg into a
+// *tls.Config, layered on top of cfg.TLSConfig if one was given. Returns nil
+// if no TLS material was configured at all.
+func buildTLSConfig(cfg ConnectionConfig) (*tls.Config, error) {
+	if cfg.TLSConfig == nil && cfg.CAFile == "" && cfg.ClientCertFile == "" && cfg.ClientKeyFile == "" {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{}
+	if cfg.TLSConfig != nil {
+		tlsConfig = cfg.TLSConfig.Clone()
+	}
+	if cfg.CAFile != "" {
+		pemCA, errRead := ioutil.ReadFile(cfg.CAFile)
+		if errRead != nil {
+			return nil, fmt.Errorf("reading CA file: %v", errRead)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pemCA) {
+			return nil, fmt.Errorf("parsing CA file: %v", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.ClientCertFile != "" || cfg.ClientKeyFile != "" {
+		cert, errPair := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if errPair != nil {
+			return nil, fmt.Errorf("loading client cert/key: %v", errPair)
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,116 @@
+package simplemongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Bucket lazily constructs a gridfs.Bucket named name on this DB and caches
+// it, mirroring the collection cache, so callers don't have to rebuild the
+// bucket on every call. Returns an error if not connected.
+func (smdb *simpleMongoDB) Bucket(name string, opts ...*options.BucketOptions) (*gridfs.Bucket, error) {
+	// read lock
+	smdb.bucketsMu.RLock()
+	bucket, ok := smdb.buckets[name]
+	smdb.bucketsMu.RUnlock()
+	if ok {
+		return bucket, nil
+	}
+	database := smdb.Database()
+	if database == nil {
+		return nil, errors.New("not connected")
+	}
+	bucketOpts := append([]*options.BucketOptions{options.GridFSBucket().SetName(name)}, opts...)
+	bucket, errBucket := gridfs.NewBucket(database, bucketOpts...)
+	if errBucket != nil {
+		return nil, fmt.Errorf("creating bucket: %v", errBucket)
+	}
+	// write lock
+	smdb.bucketsMu.Lock()
+	if smdb.buckets == nil {
+		smdb.buckets = map[string]*gridfs.Bucket{}
+	}
+	smdb.buckets[name] = bucket
+	smdb.bucketsMu.Unlock()
+	return bucket, nil
+}
+
+// UploadFromStream reads r to completion and writes it to bucketName as
+// filename, returning the new file's ObjectID and its size in bytes. If ctx
+// carries a deadline, it's applied as the upload's write deadline -- the
+// pinned driver's GridFS API predates context-aware uploads.
+func (smdb *simpleMongoDB) UploadFromStream(ctx context.Context, bucketName, filename string, r io.Reader, opts ...*options.UploadOptions) (primitive.ObjectID, int64, error) {
+	bucket, errBucket := smdb.bucketForDeadline(ctx, bucketName, (*gridfs.Bucket).SetWriteDeadline)
+	if errBucket != nil {
+		return primitive.NilObjectID, 0, errBucket
+	}
+	counted := &countingReader{r: r}
+	id, errUpload := bucket.UploadFromStream(filename, counted, opts...)
+	if errUpload != nil {
+		return primitive.NilObjectID, 0, fmt.Errorf("uploading to gridfs: %v", errUpload)
+	}
+	return id, counted.n, nil
+}
+
+// DownloadToStream writes the newest file named filename in bucketName to
+// w, returning its size in bytes. If ctx carries a deadline, it's applied
+// as the download's read deadline -- the pinned driver's GridFS API
+// predates context-aware downloads.
+func (smdb *simpleMongoDB) DownloadToStream(ctx context.Context, bucketName, filename string, w io.Writer) (int64, error) {
+	bucket, errBucket := smdb.bucketForDeadline(ctx, bucketName, (*gridfs.Bucket).SetReadDeadline)
+	if errBucket != nil {
+		return 0, errBucket
+	}
+	size, errDownload := bucket.DownloadToStreamByName(filename, w)
+	if errDownload != nil {
+		return 0, fmt.Errorf("downloading from gridfs: %v", errDownload)
+	}
+	return size, nil
+}
+
+// bucketForDeadline returns the cached bucket named name when ctx carries
+// no deadline. gridfs.Bucket's read/write deadlines are plain, unsynchronized
+// fields on the bucket struct, and Bucket() hands out the same cached
+// instance to every caller of a given name, so mutating that shared
+// instance's deadline from a single request-scoped call would both race
+// concurrent callers and stick the deadline on every later call. When ctx
+// does carry a deadline, this builds a private, uncached bucket instead and
+// applies the deadline to that one via setDeadline.
+func (smdb *simpleMongoDB) bucketForDeadline(ctx context.Context, name string, setDeadline func(*gridfs.Bucket, time.Time) error) (*gridfs.Bucket, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return smdb.Bucket(name)
+	}
+	database := smdb.Database()
+	if database == nil {
+		return nil, errors.New("not connected")
+	}
+	bucket, errBucket := gridfs.NewBucket(database, options.GridFSBucket().SetName(name))
+	if errBucket != nil {
+		return nil, fmt.Errorf("creating bucket: %v", errBucket)
+	}
+	if errDeadline := setDeadline(bucket, deadline); errDeadline != nil {
+		return nil, fmt.Errorf("setting deadline: %v", errDeadline)
+	}
+	return bucket, nil
+}
+
+// countingReader wraps an io.Reader to track how many bytes have been read
+// through it, since Bucket.UploadFromStream doesn't report upload size.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
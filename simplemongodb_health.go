@@ -0,0 +1,190 @@
+package simplemongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// defaults for ConnectOptions when left zero.
+const (
+	defaultHealthCheckInterval = 2 * time.Second
+	defaultHealthCheckRetries  = 3
+	maxReconnectBackoff        = 30 * time.Second
+)
+
+// ConnectOptions carries the parameters for ConnectWithOptions, on top of the
+// plain URI/DB/collections that Connect takes, it configures the background
+// health check supervisor that keeps the connection alive.
+type ConnectOptions struct {
+	URI         string
+	DB          string
+	Collections []string
+	// HealthCheckInterval is how often the supervisor pings the server.
+	// Defaults to 2 seconds.
+	HealthCheckInterval time.Duration
+	// HealthCheckRetries is how many consecutive ping failures are tolerated
+	// before the wrapper is marked disconnected and reconnection starts.
+	// Defaults to 3.
+	HealthCheckRetries int
+	// OnStateChange, if set, is called whenever the supervisor flips the
+	// connected state, with the error that caused the flip to disconnected.
+	OnStateChange func(connected bool, err error)
+}
+
+// ConnectWithOptions connects like Connect, then starts a background
+// goroutine that pings the server on cfg.HealthCheckInterval. After
+// cfg.HealthCheckRetries consecutive failures it marks this wrapper
+// disconnected -- IsConnected, Client, Database and Collection start
+// returning nil/false -- and keeps retrying mongo.NewClient + Connect + Ping
+// with exponential backoff until the server is reachable again, at which
+// point the collection cache is repopulated. Call Disconnect to stop the
+// supervisor and close the connection.
+func (smdb *simpleMongoDB) ConnectWithOptions(ctx context.Context, cfg ConnectOptions) error {
+	if cfg.HealthCheckInterval <= 0 {
+		cfg.HealthCheckInterval = defaultHealthCheckInterval
+	}
+	if cfg.HealthCheckRetries <= 0 {
+		cfg.HealthCheckRetries = defaultHealthCheckRetries
+	}
+	if errConnect := smdb.Connect(ctx, cfg.URI, cfg.DB, cfg.Collections...); errConnect != nil {
+		return errConnect
+	}
+	smdb.healthCfg = cfg
+	healthCtx, cancel := context.WithCancel(context.Background())
+	smdb.healthCancel = cancel
+	smdb.healthDone = make(chan struct{})
+	go smdb.superviseHealth(healthCtx)
+	return nil
+}
+
+// superviseHealth pings the server every healthCfg.HealthCheckInterval and
+// drives reconnection once the failure count reaches healthCfg.HealthCheckRetries.
+func (smdb *simpleMongoDB) superviseHealth(ctx context.Context) {
+	defer close(smdb.healthDone)
+	ticker := time.NewTicker(smdb.healthCfg.HealthCheckInterval)
+	defer ticker.Stop()
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		smdb.mu.RLock()
+		connected := smdb.isConnected()
+		client := smdb.client
+		smdb.mu.RUnlock()
+		if !connected {
+			smdb.attemptReconnect(ctx)
+			failures = 0
+			continue
+		}
+		if errPing := client.Ping(ctx, nil); errPing != nil {
+			failures++
+			if failures >= smdb.healthCfg.HealthCheckRetries {
+				failures = 0
+				smdb.markDown(errPing)
+			}
+			continue
+		}
+		failures = 0
+	}
+}
+
+// markDown tears down the current client and reports the disconnected state.
+func (smdb *simpleMongoDB) markDown(err error) {
+	smdb.mu.Lock()
+	wasConnected := smdb.isConnected()
+	if wasConnected {
+		_ = smdb.client.Disconnect(context.Background())
+	}
+	smdb.client = nil
+	smdb.database = nil
+	smdb.mu.Unlock()
+	smdb.collectionsMu.Lock()
+	smdb.collections = nil
+	smdb.collectionsMu.Unlock()
+	smdb.bucketsMu.Lock()
+	smdb.buckets = nil
+	smdb.bucketsMu.Unlock()
+	if wasConnected && smdb.healthCfg.OnStateChange != nil {
+		smdb.healthCfg.OnStateChange(false, err)
+	}
+}
+
+// attemptReconnect retries reconnect with exponential backoff until it
+// succeeds or ctx is done.
+func (smdb *simpleMongoDB) attemptReconnect(ctx context.Context) {
+	backoff := time.Second
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		if errReconnect := smdb.reconnect(ctx); errReconnect != nil {
+			if smdb.healthCfg.OnStateChange != nil {
+				smdb.healthCfg.OnStateChange(false, errReconnect)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > maxReconnectBackoff {
+				backoff = maxReconnectBackoff
+			}
+			continue
+		}
+		if smdb.healthCfg.OnStateChange != nil {
+			smdb.healthCfg.OnStateChange(true, nil)
+		}
+		return
+	}
+}
+
+// reconnect rebuilds the client, database and collection cache from the
+// original URI/DB/collection list recorded in healthCfg.
+func (smdb *simpleMongoDB) reconnect(ctx context.Context) error {
+	mdc, errClient := mongo.NewClient(options.Client().
+		ApplyURI(smdb.healthCfg.URI).
+		SetRetryWrites(false))
+	if errClient != nil {
+		return fmt.Errorf("creating client: %v", errClient)
+	}
+	if errConnect := mdc.Connect(ctx); errConnect != nil {
+		return fmt.Errorf("connecting client: %v", errConnect)
+	}
+	if errPing := mdc.Ping(ctx, nil); errPing != nil {
+		_ = mdc.Disconnect(ctx)
+		return fmt.Errorf("sending ping: %v", errPing)
+	}
+	database := mdc.Database(smdb.healthCfg.DB)
+	collections := map[string]*mongo.Collection{}
+	for _, name := range smdb.healthCfg.Collections {
+		collections[name] = database.Collection(name)
+	}
+	smdb.mu.Lock()
+	smdb.client = mdc
+	smdb.database = database
+	smdb.mu.Unlock()
+	smdb.collectionsMu.Lock()
+	smdb.collections = collections
+	smdb.collectionsMu.Unlock()
+	return nil
+}
+
+// stopHealthSupervisor stops the background supervisor, if running, and
+// waits for it to exit.
+func (smdb *simpleMongoDB) stopHealthSupervisor() {
+	if smdb.healthCancel != nil {
+		smdb.healthCancel()
+		<-smdb.healthDone
+		smdb.healthCancel = nil
+		smdb.healthDone = nil
+	}
+}
@@ -0,0 +1,37 @@
+package simplemongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/NaniteFactory/simplemongodb/migrate"
+)
+
+// Migrate registers migrations and applies every one of them that is newer
+// than the version currently recorded in the database, up to the highest
+// Version among migrations. See migrate.Migrator.Apply for the locking and
+// rollback behavior. The collection cache is invalidated afterwards so
+// collections created by a migration are picked up on the next Collection call.
+func (smdb *simpleMongoDB) Migrate(ctx context.Context, migrations []migrate.Migration) error {
+	database := smdb.Database()
+	if database == nil {
+		return errors.New("not connected")
+	}
+	migrator := migrate.New()
+	var target migrate.Version
+	for _, m := range migrations {
+		migrator.Register(m)
+		if m.Version > target {
+			target = m.Version
+		}
+	}
+	if errApply := migrator.Apply(ctx, database, target); errApply != nil {
+		return errApply
+	}
+	smdb.collectionsMu.Lock()
+	smdb.collections = map[string]*mongo.Collection{}
+	smdb.collectionsMu.Unlock()
+	return nil
+}
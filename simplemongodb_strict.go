@@ -0,0 +1,82 @@
+package simplemongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MissingCollectionsError reports that one or more collections requested at
+// connect time don't exist on the server.
+type MissingCollectionsError struct {
+	Missing []string
+	Found   []string
+}
+
+// Error implements the error interface.
+func (e *MissingCollectionsError) Error() string {
+	return fmt.Sprintf("missing collections: %v", e.Missing)
+}
+
+// ConnectStrict connects like Connect, then verifies that every requested
+// collection actually exists on the server -- Connect alone records
+// whatever name is passed even though MongoDB creates collections lazily on
+// first write. Returns a *MissingCollectionsError, and disconnects, if any
+// requested collection is absent.
+func (smdb *simpleMongoDB) ConnectStrict(ctx context.Context, uri, nameDB string, nameCollections ...string) error {
+	if errConnect := smdb.Connect(ctx, uri, nameDB, nameCollections...); errConnect != nil {
+		return errConnect
+	}
+	found, errList := smdb.Collections(ctx)
+	if errList != nil {
+		_ = smdb.Disconnect(ctx)
+		return errList
+	}
+	foundSet := make(map[string]bool, len(found))
+	for _, name := range found {
+		foundSet[name] = true
+	}
+	var missing []string
+	for _, name := range nameCollections {
+		if !foundSet[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		_ = smdb.Disconnect(ctx)
+		return &MissingCollectionsError{Missing: missing, Found: found}
+	}
+	return nil
+}
+
+// Collections returns the live list of collection names from the server.
+// Unlike Collection, this always asks the server instead of consulting the
+// collection cache.
+func (smdb *simpleMongoDB) Collections(ctx context.Context) ([]string, error) {
+	database := smdb.Database()
+	if database == nil {
+		return nil, errors.New("not connected")
+	}
+	names, errList := database.ListCollectionNames(ctx, bson.D{})
+	if errList != nil {
+		return nil, fmt.Errorf("listing collections: %v", errList)
+	}
+	return names, nil
+}
+
+// HasCollection tells whether name exists on the server. Unlike Collection,
+// this always asks the server instead of consulting the collection cache.
+func (smdb *simpleMongoDB) HasCollection(ctx context.Context, name string) (bool, error) {
+	names, errList := smdb.Collections(ctx)
+	if errList != nil {
+		return false, errList
+	}
+	for _, n := range names {
+		if n == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,94 @@
+package singlemongodb
+
+import (
+	"context"
+	"sync"
+)
+
+// registry holds every named SingleMongoDB instance, modeled on the
+// database/sql driver registry.
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]SingleMongoDB{}
+)
+
+func init() {
+	registry[""] = defaultSingleMongoDB
+}
+
+// Register adds smdb to the registry under name, so it can later be looked
+// up with Get. Panics if name is already registered, same as sql.Register.
+func Register(name string, smdb SingleMongoDB) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[name]; dup {
+		panic("singlemongodb: Register called twice for name " + name)
+	}
+	registry[name] = smdb
+}
+
+// Open connects a new instance and registers it under name. Panics if name
+// is already registered, same as Register. The name is reserved under
+// registryMu before connecting and only released (on failure) or filled in
+// (on success) afterwards, so two concurrent Open calls for the same name
+// can't both connect and race on which one leaks.
+func Open(ctx context.Context, name, uri, nameDB string, nameCollections ...string) error {
+	registryMu.Lock()
+	if _, dup := registry[name]; dup {
+		registryMu.Unlock()
+		panic("singlemongodb: Register called twice for name " + name)
+	}
+	registry[name] = nil // reserve the name while connecting
+	registryMu.Unlock()
+
+	smdb := New()
+	errConnect := smdb.Connect(ctx, uri, nameDB, nameCollections...)
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if errConnect != nil {
+		delete(registry, name)
+		return errConnect
+	}
+	registry[name] = smdb
+	return nil
+}
+
+// Get returns the instance registered under name, or nil if none is.
+func Get(name string) SingleMongoDB {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return registry[name]
+}
+
+// Names returns the name of every registered instance, in no particular order.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
+
+// CloseAll disconnects every registered instance and empties the registry.
+// The first error encountered, if any, is returned; disconnection of the
+// remaining instances is still attempted.
+func CloseAll(ctx context.Context) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	var firstErr error
+	for name, smdb := range registry {
+		if smdb == nil {
+			// still being connected by a concurrent Open; leave its
+			// reservation in place for that call to resolve
+			continue
+		}
+		if errDisconnect := smdb.Disconnect(ctx); errDisconnect != nil && firstErr == nil {
+			firstErr = errDisconnect
+		}
+		delete(registry, name)
+	}
+	return firstErr
+}
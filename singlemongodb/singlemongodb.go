@@ -4,7 +4,6 @@ package singlemongodb
 import (
 	"context"
 	"errors"
-	"fmt"
 	"sync"
 
 	"go.mongodb.org/mongo-driver/mongo"
@@ -56,6 +55,8 @@ func Collection(name string, opts ...*options.CollectionOptions) *mongo.Collecti
 type SingleMongoDB interface {
 	New() SingleMongoDB
 	Connect(ctx context.Context, uri, nameDB string, nameCollections ...string) error
+	ConnectWithConfig(ctx context.Context, cfg ConnectionConfig) error
+	ConnectWithOptions(ctx context.Context, cfg ConnectOptions) error
 	Disconnect(ctx context.Context) error
 	Client() *mongo.Client
 	Database() *mongo.Database
@@ -69,6 +70,13 @@ type singleMongoDB struct {
 	client      *mongo.Client
 	database    *mongo.Database
 	collections map[string]*mongo.Collection
+
+	// healthCfg, healthCancel and healthDone back the background health
+	// check supervisor started by ConnectWithOptions. healthCancel is nil
+	// when no supervisor is running.
+	healthCfg    ConnectOptions
+	healthCancel context.CancelFunc
+	healthDone   chan struct{}
 }
 
 func (smdb *singleMongoDB) isConnected() bool {
@@ -93,54 +101,19 @@ func (smdb *singleMongoDB) New() SingleMongoDB {
 // Connect to a single DB with a single mongo client.
 // Errors if it cannot reach any of desired database or collections.
 // Call Disconnect method to close down connection.
+// This is a thin wrapper around ConnectWithConfig for the common case of a
+// bare URI with no TLS/credential configuration.
 func (smdb *singleMongoDB) Connect(ctx context.Context, uri, nameDB string, nameCollections ...string) error {
-	smdb.mu.Lock()
-	defer smdb.mu.Unlock()
-	// validate
-	if smdb.isConnected() {
-		return errors.New("already connected")
-	}
-	{ // construct a client
-		// the return is assign to a local variable in case of error violating the global
-		mdc, errClient := mongo.NewClient(options.Client().
-			ApplyURI(uri).
-			SetRetryWrites(false))
-		if errClient != nil {
-			return fmt.Errorf("creating client: %v", errClient)
-		}
-		smdb.client = mdc
-	}
-	// connect
-	if errConnect := smdb.client.Connect(ctx); errConnect != nil {
-		return fmt.Errorf("connecting client: %v", errConnect)
-	}
-	// ping
-	if errPing := smdb.client.Ping(ctx, nil); errPing != nil {
-		smdb.disconnect()
-		return fmt.Errorf("sending ping: %v", errPing)
-	}
-	// get database
-	smdb.database = smdb.client.Database(nameDB)
-	if smdb.database == nil {
-		smdb.disconnect()
-		return fmt.Errorf("cannot get database: %v", nameDB)
-	}
-	// set collection
-	smdb.collections = map[string]*mongo.Collection{}
-	for _, nameCollection := range nameCollections {
-		collection := smdb.database.Collection(nameCollection)
-		if collection == nil {
-			smdb.disconnect()
-			return fmt.Errorf("cannot get collection: %v", nameCollection)
-		}
-		smdb.collections[nameCollection] = smdb.database.Collection(nameCollection)
-	}
-	// return
-	return nil
+	return smdb.ConnectWithConfig(ctx, ConnectionConfig{
+		URI:         uri,
+		DB:          nameDB,
+		Collections: nameCollections,
+	})
 }
 
 // Disconnect the connection to DB.
 func (smdb *singleMongoDB) Disconnect(ctx context.Context) error {
+	smdb.stopHealthSupervisor()
 	smdb.mu.RLock()
 	defer smdb.mu.RUnlock()
 	if !smdb.isConnected() {